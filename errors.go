@@ -0,0 +1,77 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Known values of APIError.Reason, see https://docs.gemini.com/rest-api/#error-reasons
+const (
+	ReasonInvalidNonce = "InvalidNonce"
+	ReasonRateLimit = "RateLimit"
+	ReasonInsufficientFunds = "InsufficientFunds"
+	ReasonMarketNotOpen = "MarketNotOpen"
+	ReasonInvalidSignature = "InvalidSignature"
+)
+
+// APIError represents a non-success response from the Gemini API, whether
+// surfaced as a non-2xx HTTP status or a {"result":"error",...} envelope
+type APIError struct {
+	Status int
+	Result string
+	Reason string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gemini: %s: %s (http status %d)", e.Reason, e.Message, e.Status)
+}
+
+// errorEnvelope mirrors the {"result":"error","reason":"...","message":"..."} body
+// Gemini returns for both non-2xx responses and some 200 responses
+type errorEnvelope struct {
+	Result string `json:"result"`
+	Reason string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// parseAPIError inspects an API response and returns an *APIError if it represents
+// a failure, or nil if it looks like a successful response
+func parseAPIError(status int, body []byte) error {
+	envelope := errorEnvelope{}
+	_ = json.Unmarshal(body, &envelope)
+	if status >= 200 && status < 300 && envelope.Result != "error" {
+		return nil
+	}
+	return &APIError{
+		Status: status,
+		Result: envelope.Result,
+		Reason: envelope.Reason,
+		Message: envelope.Message,
+	}
+}
+
+// IsRateLimited reports whether err is an APIError with Reason RateLimit
+func IsRateLimited(err error) bool {
+	return hasReason(err, ReasonRateLimit)
+}
+
+// IsInvalidNonce reports whether err is an APIError with Reason InvalidNonce
+func IsInvalidNonce(err error) bool {
+	return hasReason(err, ReasonInvalidNonce)
+}
+
+// IsInsufficientFunds reports whether err is an APIError with Reason InsufficientFunds
+func IsInsufficientFunds(err error) bool {
+	return hasReason(err, ReasonInsufficientFunds)
+}
+
+// IsMarketNotOpen reports whether err is an APIError with Reason MarketNotOpen
+func IsMarketNotOpen(err error) bool {
+	return hasReason(err, ReasonMarketNotOpen)
+}
+
+func hasReason(err error, reason string) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.Reason == reason
+}
@@ -0,0 +1,42 @@
+package gemini
+
+import "testing"
+
+func TestParseAPIError(t *testing.T) {
+	if err := parseAPIError(200, []byte(`{"result":"ok"}`)); err != nil {
+		t.Errorf("expected nil for a 200 non-error body, got %v", err)
+	}
+	err := parseAPIError(200, []byte(`{"result":"error","reason":"RateLimit","message":"too many requests"}`))
+	if err == nil {
+		t.Fatal("expected an error for a result:error body")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Reason != ReasonRateLimit || apiErr.Message != "too many requests" {
+		t.Errorf("unexpected APIError fields: %+v", apiErr)
+	}
+	err = parseAPIError(500, []byte(`not json`))
+	if err == nil {
+		t.Fatal("expected an error for a non-2xx status")
+	}
+}
+
+func TestIsHelpers(t *testing.T) {
+	rateLimited := &APIError{Reason: ReasonRateLimit}
+	invalidNonce := &APIError{Reason: ReasonInvalidNonce}
+
+	if !IsRateLimited(rateLimited) {
+		t.Error("IsRateLimited should be true for a RateLimit APIError")
+	}
+	if IsRateLimited(invalidNonce) {
+		t.Error("IsRateLimited should be false for an InvalidNonce APIError")
+	}
+	if !IsInvalidNonce(invalidNonce) {
+		t.Error("IsInvalidNonce should be true for an InvalidNonce APIError")
+	}
+	if IsInsufficientFunds(rateLimited) {
+		t.Error("IsInsufficientFunds should be false for a RateLimit APIError")
+	}
+}
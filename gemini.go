@@ -8,15 +8,42 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"os"
+	"sync"
 	"time"
 )
 
+// authRetryDelay is how long AuthAPIReq waits before retrying a RateLimit or
+// InvalidNonce failure
+const authRetryDelay = 500 * time.Millisecond
+
+// logger is used throughout the package for WARN/ERROR diagnostics.
+var logger = log.New(os.Stderr, "gemini ", log.LstdFlags)
+
 type GeminiAPI struct {
 	BaseURL string
 	ApiKey string
 	ApiSecret string
-	Nonce int64
+
+	nonceSource NonceSource
+	authMu sync.Mutex
+
+	symbolCacheMu sync.Mutex
+	symbolCache map[string]symbolCacheEntry
+	symbolCacheTTL time.Duration
+}
+
+// GeminiAPIOption configures optional behavior on NewGeminiAPI
+type GeminiAPIOption func(*GeminiAPI)
+
+// WithNonceSource overrides the default MonotonicNonce, e.g. with a
+// PersistentNonce or a caller-supplied implementation shared across processes
+func WithNonceSource(n NonceSource) GeminiAPIOption {
+	return func(ga *GeminiAPI) {
+		ga.nonceSource = n
+	}
 }
 
 // Ticker stores the json returned by the pubticker endpoint
@@ -92,6 +119,8 @@ type OrderPlaceReq struct {
 	Side string `json:"side"`
 	Type string `json:"type"`
 	ClientId string `json:"client_order_id"`
+	Options []string `json:"options,omitempty"`
+	StopPrice string `json:"stop_price,omitempty"`
 }
 
 func (r *OrderPlaceReq) GetPayload() []byte {
@@ -99,15 +128,56 @@ func (r *OrderPlaceReq) GetPayload() []byte {
 	return data
 }
 
-// AuthAPIReq makes a signed api request to gemini
+// LimitOrderOption is an execution option accepted by the /v1/order/new
+// endpoint's "options" array
+type LimitOrderOption string
+
+const (
+	OptionMakerOrCancel LimitOrderOption = "maker-or-cancel"
+	OptionImmediateOrCancel LimitOrderOption = "immediate-or-cancel"
+	OptionFillOrKill LimitOrderOption = "fill-or-kill"
+	OptionAuctionOnly LimitOrderOption = "auction-only"
+	OptionIndicationOfInterest LimitOrderOption = "indication-of-interest"
+)
+
+// validateLimitOrderOptions rejects combinations of options that Gemini
+// itself would reject, since at most one execution option may be set
+func validateLimitOrderOptions(options []LimitOrderOption) error {
+	if len(options) > 1 {
+		return fmt.Errorf("only one order option may be set, got %v", options)
+	}
+	return nil
+}
+
+// AuthAPIReq makes a signed api request to gemini. RateLimit and InvalidNonce
+// errors are retried once before being returned; an InvalidNonce retry first
+// reseeds the nonce source (if it supports it) so the retry doesn't reuse a
+// value Gemini has already rejected.
 func (ga *GeminiAPI) AuthAPIReq(r Request) ([]byte, error) {
+	body, err := ga.doAuthAPIReq(r)
+	if err != nil && (IsRateLimited(err) || IsInvalidNonce(err)) {
+		logger.Printf("WARN: %s, retrying %s once\n", err, r.GetRoute())
+		if IsInvalidNonce(err) {
+			if reseeder, ok := ga.nonceSource.(NonceReseeder); ok {
+				reseeder.Reseed()
+			}
+		}
+		time.Sleep(authRetryDelay)
+		body, err = ga.doAuthAPIReq(r)
+	}
+	return body, err
+}
+
+// doAuthAPIReq makes a single signed api request attempt to gemini
+func (ga *GeminiAPI) doAuthAPIReq(r Request) ([]byte, error) {
 	client := &http.Client{}
-	r.SetNonce(ga.Nonce)
-	ga.Nonce++
+	ga.authMu.Lock()
+	r.SetNonce(ga.nonceSource.Next())
+	ga.authMu.Unlock()
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s", ga.BaseURL, r.GetRoute()), nil)
 	if err != nil {
 		logger.Printf("ERROR: Failed to POST authenticated request to: %s\n", r.GetRoute())
-		return []byte{}, nil
+		return []byte{}, err
 	}
 	base64Payload := base64.StdEncoding.EncodeToString(r.GetPayload())
 	h := hmac.New(sha512.New384, []byte(ga.ApiSecret))
@@ -119,13 +189,16 @@ func (ga *GeminiAPI) AuthAPIReq(r Request) ([]byte, error) {
 	resp, err := client.Do(req)
 	if err != nil {
 		logger.Printf("ERROR: failed to POST authenticated request: %s\n", r.GetRoute())
-		return []byte{}, nil
+		return []byte{}, err
 	}
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		logger.Printf("ERROR: failed to read response body\n")
-		return []byte{}, nil
+		return []byte{}, err
+	}
+	if apiErr := parseAPIError(resp.StatusCode, body); apiErr != nil {
+		return []byte{}, apiErr
 	}
 	return body, nil
 }
@@ -193,16 +266,30 @@ func (ga *GeminiAPI) CancelAll() {
 	ga.AuthAPIReq(&input)
 }
 
-// PlaceLimitOrder takes a direction, ticker, client_id, amount, and price and returns an Order object
-func (ga *GeminiAPI) PlaceLimitOrder(direction, ticker, client_id string, amount, price float64) (Order, error) {
-	amountStr := fmt.Sprintf("%0.6f", amount)
-	priceStr := ""
-	if ticker == "btcusd" || ticker == "ethusd" {
-		priceStr = fmt.Sprintf("%0.2f", price)
-	} else if ticker == "ethbtc" {
-		priceStr = fmt.Sprintf("%0.5f", price)
-	} else {
-		panic("Unsupported ticker for placing orders")
+// PlaceLimitOrder takes a direction, ticker, client_id, amount, and price and returns an Order object.
+// At most one LimitOrderOption may be passed to control execution (maker-or-cancel, IOC, FOK, etc);
+// passing more than one returns an error without placing the order.
+func (ga *GeminiAPI) PlaceLimitOrder(direction, ticker, client_id string, amount, price float64, options ...LimitOrderOption) (Order, error) {
+	details, err := ga.cachedSymbolDetails(ticker)
+	if err != nil {
+		logger.Printf("ERROR: failed to look up symbol details for %s\n", ticker)
+		return Order{}, err
+	}
+	if details.TickSize <= 0 || details.QuoteIncrement <= 0 {
+		logger.Printf("ERROR: symbol details for %s have no usable tick size/quote increment\n", ticker)
+		return Order{}, fmt.Errorf("gemini: symbol %q has no usable tick size/quote increment", ticker)
+	}
+	amount = roundToIncrement(amount, details.TickSize)
+	price = roundToIncrement(price, details.QuoteIncrement)
+	amountStr := fmt.Sprintf("%0.*f", decimalPlaces(details.TickSize), amount)
+	priceStr := fmt.Sprintf("%0.*f", decimalPlaces(details.QuoteIncrement), price)
+	if err := validateLimitOrderOptions(options); err != nil {
+		logger.Printf("ERROR: invalid order options: %s\n", err)
+		return Order{}, err
+	}
+	optionStrs := make([]string, len(options))
+	for i, opt := range options {
+		optionStrs[i] = string(opt)
 	}
 	body, err := ga.AuthAPIReq(&OrderPlaceReq{
 		BaseRequest: NewBaseRequest("/v1/order/new"),
@@ -212,6 +299,7 @@ func (ga *GeminiAPI) PlaceLimitOrder(direction, ticker, client_id string, amount
 		Side: direction,
 		Type: "exchange limit",
 		ClientId: client_id,
+		Options: optionStrs,
 	})
 	if err != nil {
 		logger.Printf("ERROR: error placing order\n")
@@ -226,13 +314,19 @@ func (ga *GeminiAPI) PlaceLimitOrder(direction, ticker, client_id string, amount
 	return order, nil
 }
 
-// NewGeminiAPI initializes a GeminiAPI object
-func NewGeminiAPI(baseurl, apikey, apisecret string) *GeminiAPI {
+// NewGeminiAPI initializes a GeminiAPI object. By default nonces are produced by a
+// MonotonicNonce; pass WithNonceSource to use a PersistentNonce or a custom NonceSource.
+func NewGeminiAPI(baseurl, apikey, apisecret string, opts ...GeminiAPIOption) *GeminiAPI {
 	ga := &GeminiAPI{
 		BaseURL: baseurl,
 		ApiKey: apikey,
 		ApiSecret: apisecret,
-		Nonce: time.Now().UnixNano(),
+		nonceSource: NewMonotonicNonce(),
+		symbolCache: map[string]symbolCacheEntry{},
+		symbolCacheTTL: 1 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(ga)
 	}
 	logger.Println("Initialized Gemini API")
 	return ga
@@ -0,0 +1,21 @@
+package gemini
+
+import "testing"
+
+func TestValidateLimitOrderOptions(t *testing.T) {
+	cases := []struct {
+		name string
+		options []LimitOrderOption
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"single", []LimitOrderOption{OptionMakerOrCancel}, false},
+		{"two options conflict", []LimitOrderOption{OptionMakerOrCancel, OptionImmediateOrCancel}, true},
+	}
+	for _, c := range cases {
+		err := validateLimitOrderOptions(c.options)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: validateLimitOrderOptions(%v) error = %v, wantErr %v", c.name, c.options, err, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// BookEntry stores a single price level returned by the order book endpoint
+type BookEntry struct {
+	Price float64 `json:"price,string"`
+	Amount float64 `json:"amount,string"`
+	Timestamp int `json:"timestamp,string"`
+}
+
+// OrderBook stores the json returned by the book endpoint
+type OrderBook struct {
+	Bids []BookEntry `json:"bids"`
+	Asks []BookEntry `json:"asks"`
+}
+
+// Trade stores a single public trade returned by the trades endpoint
+type Trade struct {
+	Timestamp int `json:"timestamp"`
+	TimestampMs int64 `json:"timestampms"`
+	TradeId int64 `json:"tid"`
+	Price float64 `json:"price,string"`
+	Amount float64 `json:"amount,string"`
+	ExchangeId int64 `json:"exchange"`
+	Type string `json:"type"`
+}
+
+// Candle stores a single OHLC candle returned by the v2 candles endpoint.
+// Gemini returns each candle as a 6 element array: [time, open, high, low, close, volume]
+type Candle struct {
+	Timestamp int64
+	Open float64
+	High float64
+	Low float64
+	Close float64
+	Volume float64
+}
+
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	var raw [6]float64
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	c.Timestamp = int64(raw[0])
+	c.Open = raw[1]
+	c.High = raw[2]
+	c.Low = raw[3]
+	c.Close = raw[4]
+	c.Volume = raw[5]
+	return nil
+}
+
+// CandleInterval is a timeframe accepted by the v2 candles endpoint
+type CandleInterval string
+
+const (
+	Candle1m CandleInterval = "1m"
+	Candle5m CandleInterval = "5m"
+	Candle15m CandleInterval = "15m"
+	Candle30m CandleInterval = "30m"
+	Candle1hr CandleInterval = "1hr"
+	Candle6hr CandleInterval = "6hr"
+	Candle1day CandleInterval = "1day"
+)
+
+// GetOrderBook returns the current order book for symbol. limitBids/limitAsks cap the
+// number of price points returned on each side; a value of 0 requests the full book.
+func (ga *GeminiAPI) GetOrderBook(symbol string, limitBids, limitAsks int) (OrderBook, error) {
+	bookUrl := fmt.Sprintf("%s/v1/book/%s?limit_bids=%d&limit_asks=%d", ga.BaseURL, symbol, limitBids, limitAsks)
+	resp, err := http.Get(bookUrl)
+	if err != nil {
+		logger.Printf("ERROR: Failed to get order book for %s\n", symbol)
+		return OrderBook{}, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		logger.Printf("ERROR: Failed to read order book from response\n")
+		return OrderBook{}, err
+	}
+	book := OrderBook{}
+	err = json.Unmarshal(body, &book)
+	if err != nil {
+		logger.Printf("ERROR: Failed to decode order book from response\n")
+		return OrderBook{}, err
+	}
+	return book, nil
+}
+
+// GetTrades returns public trades for symbol since the given time, capped at limit entries.
+// A zero since fetches the most recent trades.
+func (ga *GeminiAPI) GetTrades(symbol string, since time.Time, limit int) ([]Trade, error) {
+	params := url.Values{}
+	if !since.IsZero() {
+		params.Set("timestamp", strconv.FormatInt(since.Unix(), 10))
+	}
+	if limit > 0 {
+		params.Set("limit_trades", strconv.Itoa(limit))
+	}
+	tradesUrl := fmt.Sprintf("%s/v1/trades/%s?%s", ga.BaseURL, symbol, params.Encode())
+	resp, err := http.Get(tradesUrl)
+	if err != nil {
+		logger.Printf("ERROR: Failed to get trades for %s\n", symbol)
+		return []Trade{}, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		logger.Printf("ERROR: Failed to read trades from response\n")
+		return []Trade{}, err
+	}
+	trades := []Trade{}
+	err = json.Unmarshal(body, &trades)
+	if err != nil {
+		logger.Printf("ERROR: Failed to decode trades from response\n")
+		return []Trade{}, err
+	}
+	return trades, nil
+}
+
+// GetCandles returns OHLC candles for symbol at the given timeframe
+func (ga *GeminiAPI) GetCandles(symbol string, timeframe CandleInterval) ([]Candle, error) {
+	candlesUrl := fmt.Sprintf("%s/v2/candles/%s/%s", ga.BaseURL, symbol, timeframe)
+	resp, err := http.Get(candlesUrl)
+	if err != nil {
+		logger.Printf("ERROR: Failed to get candles for %s\n", symbol)
+		return []Candle{}, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		logger.Printf("ERROR: Failed to read candles from response\n")
+		return []Candle{}, err
+	}
+	candles := []Candle{}
+	err = json.Unmarshal(body, &candles)
+	if err != nil {
+		logger.Printf("ERROR: Failed to decode candles from response\n")
+		return []Candle{}, err
+	}
+	return candles, nil
+}
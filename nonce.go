@@ -0,0 +1,98 @@
+package gemini
+
+import (
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NonceSource produces strictly increasing nonces for signed API requests.
+// Implementations must be safe for concurrent use.
+type NonceSource interface {
+	Next() int64
+}
+
+// NonceReseeder is implemented by NonceSource types that can jump their
+// current value ahead of wall-clock time. AuthAPIReq calls Reseed, if the
+// configured NonceSource implements it, after an InvalidNonce rejection,
+// since a single Next() bump is not enough to clear a nonce that is already
+// behind the value Gemini last saw.
+type NonceReseeder interface {
+	Reseed()
+}
+
+// MonotonicNonce is an in-process NonceSource backed by an atomic counter
+// seeded from the current time. It is safe for concurrent use but does not
+// survive a process restart, so a restarted process may reuse nonces if the
+// previous process issued nonces ahead of wall-clock time.
+type MonotonicNonce struct {
+	counter int64
+}
+
+// NewMonotonicNonce seeds a MonotonicNonce from time.Now().UnixNano()
+func NewMonotonicNonce() *MonotonicNonce {
+	return &MonotonicNonce{counter: time.Now().UnixNano()}
+}
+
+func (n *MonotonicNonce) Next() int64 {
+	return atomic.AddInt64(&n.counter, 1)
+}
+
+// Reseed jumps the counter ahead to the current time if that is higher than
+// the counter's present value, so a rejected InvalidNonce isn't retried with
+// a value Gemini has already seen.
+func (n *MonotonicNonce) Reseed() {
+	if now := time.Now().UnixNano(); now > atomic.LoadInt64(&n.counter) {
+		atomic.StoreInt64(&n.counter, now)
+	}
+}
+
+// PersistentNonce is a NonceSource backed by a file, so a restarted process
+// picks up where the last one left off instead of reusing nonces.
+type PersistentNonce struct {
+	path string
+	mu sync.Mutex
+	last int64
+}
+
+// NewPersistentNonce loads the last-used nonce from path, if present, and
+// advances it to the current time if that would be higher.
+func NewPersistentNonce(path string) (*PersistentNonce, error) {
+	n := &PersistentNonce{path: path}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		if v, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			n.last = v
+		}
+	}
+	if now := time.Now().UnixNano(); now > n.last {
+		n.last = now
+	}
+	return n, nil
+}
+
+func (n *PersistentNonce) Next() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.last++
+	if err := ioutil.WriteFile(n.path, []byte(strconv.FormatInt(n.last, 10)), 0600); err != nil {
+		logger.Printf("ERROR: failed to persist nonce to %s: %s\n", n.path, err)
+	}
+	return n.last
+}
+
+// Reseed jumps last ahead to the current time if that is higher than last's
+// present value, so a rejected InvalidNonce isn't retried with a value
+// Gemini has already seen.
+func (n *PersistentNonce) Reseed() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if now := time.Now().UnixNano(); now > n.last {
+		n.last = now
+		if err := ioutil.WriteFile(n.path, []byte(strconv.FormatInt(n.last, 10)), 0600); err != nil {
+			logger.Printf("ERROR: failed to persist nonce to %s: %s\n", n.path, err)
+		}
+	}
+}
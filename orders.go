@@ -0,0 +1,117 @@
+package gemini
+
+import (
+	"fmt"
+	"encoding/json"
+)
+
+// CancelReq is used to cancel a single order by order_id or client_order_id
+type CancelReq struct {
+	BaseRequest
+	OrderId string `json:"order_id,omitempty"`
+	ClientOrderId string `json:"client_order_id,omitempty"`
+}
+
+func (r *CancelReq) GetPayload() []byte {
+	data, _ := json.Marshal(r)
+	return data
+}
+
+// ReplaceOrderResult holds the outcome of a cancel/replace pair performed by ReplaceOrder
+type ReplaceOrderResult struct {
+	Cancelled Order
+	New Order
+}
+
+// PlaceStopLimitOrder places a stop-limit order: once the market trades at stopPrice
+// the order becomes a standing limit order at price
+func (ga *GeminiAPI) PlaceStopLimitOrder(direction, ticker, clientID string, amount, price, stopPrice float64) (Order, error) {
+	details, err := ga.cachedSymbolDetails(ticker)
+	if err != nil {
+		logger.Printf("ERROR: failed to look up symbol details for %s\n", ticker)
+		return Order{}, err
+	}
+	if details.TickSize <= 0 || details.QuoteIncrement <= 0 {
+		logger.Printf("ERROR: symbol details for %s have no usable tick size/quote increment\n", ticker)
+		return Order{}, fmt.Errorf("gemini: symbol %q has no usable tick size/quote increment", ticker)
+	}
+	amount = roundToIncrement(amount, details.TickSize)
+	price = roundToIncrement(price, details.QuoteIncrement)
+	stopPrice = roundToIncrement(stopPrice, details.QuoteIncrement)
+	amountStr := fmt.Sprintf("%0.*f", decimalPlaces(details.TickSize), amount)
+	priceStr := fmt.Sprintf("%0.*f", decimalPlaces(details.QuoteIncrement), price)
+	stopPriceStr := fmt.Sprintf("%0.*f", decimalPlaces(details.QuoteIncrement), stopPrice)
+	body, err := ga.AuthAPIReq(&OrderPlaceReq{
+		BaseRequest: NewBaseRequest("/v1/order/new"),
+		Symbol: ticker,
+		Amount: amountStr,
+		Price: priceStr,
+		StopPrice: stopPriceStr,
+		Side: direction,
+		Type: "exchange stop limit",
+		ClientId: clientID,
+	})
+	if err != nil {
+		logger.Printf("ERROR: error placing stop limit order\n")
+		return Order{}, err
+	}
+	order := Order{}
+	err = json.Unmarshal(body, &order)
+	if err != nil {
+		logger.Printf("ERROR: error decoding stop limit order placement json response\n")
+		return Order{}, err
+	}
+	return order, nil
+}
+
+// CancelOrder cancels a single order by its order_id
+func (ga *GeminiAPI) CancelOrder(orderID string) (Order, error) {
+	input := CancelReq{
+		BaseRequest: NewBaseRequest("/v1/order/cancel"),
+		OrderId: orderID,
+	}
+	body, err := ga.AuthAPIReq(&input)
+	if err != nil {
+		logger.Printf("ERROR: failed to cancel order %s\n", orderID)
+		return Order{}, err
+	}
+	order := Order{}
+	err = json.Unmarshal(body, &order)
+	if err != nil {
+		logger.Printf("ERROR: error decoding cancel order json response\n")
+		return Order{}, err
+	}
+	return order, nil
+}
+
+// CancelByClientID cancels the open order carrying the given client_order_id
+func (ga *GeminiAPI) CancelByClientID(clientID string) (Order, error) {
+	orders, err := ga.GetOrderStatus()
+	if err != nil {
+		logger.Printf("ERROR: failed to look up orders to cancel client id %s\n", clientID)
+		return Order{}, err
+	}
+	for _, order := range orders {
+		if order.ClientId == clientID {
+			return ga.CancelOrder(order.OrderId)
+		}
+	}
+	return Order{}, fmt.Errorf("no open order found with client_order_id %s", clientID)
+}
+
+// ReplaceOrder cancels orderID and places a new limit order at newAmount/newPrice under
+// newClientID, returning both the cancelled order and the newly placed order. newClientID
+// must not reuse orderID's client_order_id, or CancelByClientID becomes ambiguous between the two.
+func (ga *GeminiAPI) ReplaceOrder(orderID, newClientID string, newAmount, newPrice float64) (ReplaceOrderResult, error) {
+	cancelled, err := ga.CancelOrder(orderID)
+	if err != nil {
+		logger.Printf("ERROR: failed to cancel order %s for replace\n", orderID)
+		return ReplaceOrderResult{}, err
+	}
+	newOrder, err := ga.PlaceLimitOrder(cancelled.Side, cancelled.Symbol, newClientID, newAmount, newPrice)
+	if err != nil {
+		logger.Printf("ERROR: failed to place replacement order for %s\n", orderID)
+		return ReplaceOrderResult{Cancelled: cancelled}, err
+	}
+	return ReplaceOrderResult{Cancelled: cancelled, New: newOrder}, nil
+}
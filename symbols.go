@@ -0,0 +1,119 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"time"
+)
+
+// SymbolDetails stores the json returned by the symbols details endpoint
+type SymbolDetails struct {
+	Symbol string `json:"symbol"`
+	BaseCurrency string `json:"base_currency"`
+	QuoteCurrency string `json:"quote_currency"`
+	TickSize float64 `json:"tick_size"`
+	QuoteIncrement float64 `json:"quote_increment"`
+	MinOrderSize float64 `json:"min_order_size,string"`
+	Status string `json:"status"`
+}
+
+type symbolCacheEntry struct {
+	details SymbolDetails
+	fetchedAt time.Time
+}
+
+// GetSymbols returns the list of all symbols traded on Gemini
+func (ga *GeminiAPI) GetSymbols() ([]string, error) {
+	symbolsUrl := "/v1/symbols"
+	resp, err := http.Get(fmt.Sprintf("%s%s", ga.BaseURL, symbolsUrl))
+	if err != nil {
+		logger.Printf("ERROR: Failed to get symbols\n")
+		return []string{}, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		logger.Printf("ERROR: Failed to read symbols from response\n")
+		return []string{}, err
+	}
+	symbols := []string{}
+	err = json.Unmarshal(body, &symbols)
+	if err != nil {
+		logger.Printf("ERROR: Failed to decode symbols from response\n")
+		return []string{}, err
+	}
+	return symbols, nil
+}
+
+// GetSymbolDetails fetches the trading rules for a symbol, bypassing the cache
+func (ga *GeminiAPI) GetSymbolDetails(symbol string) (SymbolDetails, error) {
+	detailsUrl := fmt.Sprintf("/v1/symbols/details/%s", symbol)
+	resp, err := http.Get(fmt.Sprintf("%s%s", ga.BaseURL, detailsUrl))
+	if err != nil {
+		logger.Printf("ERROR: Failed to get symbol details for %s\n", symbol)
+		return SymbolDetails{}, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		logger.Printf("ERROR: Failed to read symbol details from response\n")
+		return SymbolDetails{}, err
+	}
+	if apiErr := parseAPIError(resp.StatusCode, body); apiErr != nil {
+		logger.Printf("ERROR: Failed to get symbol details for %s: %s\n", symbol, apiErr)
+		return SymbolDetails{}, apiErr
+	}
+	details := SymbolDetails{}
+	err = json.Unmarshal(body, &details)
+	if err != nil {
+		logger.Printf("ERROR: Failed to decode symbol details from response\n")
+		return SymbolDetails{}, err
+	}
+	if details.Symbol == "" || details.Status == "" {
+		logger.Printf("ERROR: unknown symbol %s\n", symbol)
+		return SymbolDetails{}, fmt.Errorf("gemini: unknown symbol %q", symbol)
+	}
+	return details, nil
+}
+
+// cachedSymbolDetails returns the SymbolDetails for symbol, fetching and
+// caching it if it is missing or older than symbolCacheTTL
+func (ga *GeminiAPI) cachedSymbolDetails(symbol string) (SymbolDetails, error) {
+	ga.symbolCacheMu.Lock()
+	entry, ok := ga.symbolCache[symbol]
+	ga.symbolCacheMu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < ga.symbolCacheTTL {
+		return entry.details, nil
+	}
+	details, err := ga.GetSymbolDetails(symbol)
+	if err != nil {
+		return SymbolDetails{}, err
+	}
+	ga.symbolCacheMu.Lock()
+	ga.symbolCache[symbol] = symbolCacheEntry{details: details, fetchedAt: time.Now()}
+	ga.symbolCacheMu.Unlock()
+	return details, nil
+}
+
+// roundToIncrement rounds v down to the nearest multiple of increment
+func roundToIncrement(v, increment float64) float64 {
+	if increment <= 0 {
+		return v
+	}
+	return math.Floor(v/increment) * increment
+}
+
+// decimalPlaces returns the number of decimal digits needed to represent
+// increment exactly, e.g. 0.01 -> 2, 0.00001 -> 5, capped at 8
+func decimalPlaces(increment float64) int {
+	for places := 0; places <= 8; places++ {
+		scaled := increment * math.Pow(10, float64(places))
+		if math.Abs(scaled-math.Round(scaled)) < 1e-9 {
+			return places
+		}
+	}
+	return 8
+}
@@ -0,0 +1,39 @@
+package gemini
+
+import "testing"
+
+func TestRoundToIncrement(t *testing.T) {
+	cases := []struct {
+		v, increment, want float64
+	}{
+		{1.23456, 0.01, 1.23},
+		{1.999, 0.01, 1.99},
+		{5, 0, 5},
+		{0.000123, 0.00001, 0.00012},
+	}
+	const epsilon = 1e-9
+	for _, c := range cases {
+		got := roundToIncrement(c.v, c.increment)
+		if diff := got - c.want; diff < -epsilon || diff > epsilon {
+			t.Errorf("roundToIncrement(%v, %v) = %v, want %v", c.v, c.increment, got, c.want)
+		}
+	}
+}
+
+func TestDecimalPlaces(t *testing.T) {
+	cases := []struct {
+		increment float64
+		want int
+	}{
+		{0.01, 2},
+		{0.00001, 5},
+		{1, 0},
+		{0.1, 1},
+	}
+	for _, c := range cases {
+		got := decimalPlaces(c.increment)
+		if got != c.want {
+			t.Errorf("decimalPlaces(%v) = %d, want %d", c.increment, got, c.want)
+		}
+	}
+}
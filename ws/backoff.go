@@ -0,0 +1,27 @@
+package ws
+
+import "time"
+
+// backoff produces exponentially increasing reconnect delays, capped at max
+type backoff struct {
+	base time.Duration
+	max time.Duration
+	attempt int
+}
+
+func newBackoff(base, max time.Duration) *backoff {
+	return &backoff{base: base, max: max}
+}
+
+func (b *backoff) next() time.Duration {
+	d := b.base << uint(b.attempt)
+	if d <= 0 || d > b.max {
+		d = b.max
+	}
+	b.attempt++
+	return d
+}
+
+func (b *backoff) reset() {
+	b.attempt = 0
+}
@@ -0,0 +1,19 @@
+package ws
+
+import "testing"
+import "time"
+
+func TestBackoffNext(t *testing.T) {
+	b := newBackoff(time.Second, 10*time.Second)
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		got := b.next()
+		if got != w {
+			t.Errorf("next() call %d = %v, want %v", i, got, w)
+		}
+	}
+	b.reset()
+	if got := b.next(); got != time.Second {
+		t.Errorf("next() after reset = %v, want %v", got, time.Second)
+	}
+}
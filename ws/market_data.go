@@ -0,0 +1,273 @@
+// Package ws provides streaming access to Gemini's market-data and
+// order-events websocket APIs, which the REST-only gemini package cannot offer.
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ChangeEvent is emitted whenever a price level in the order book changes
+type ChangeEvent struct {
+	Side string
+	Price float64
+	Remaining float64
+	Delta float64
+	Reason string
+}
+
+// TradeEvent is emitted whenever a trade executes on the symbol
+type TradeEvent struct {
+	TradeId int64
+	Price float64
+	Amount float64
+	MakerSide string
+}
+
+// AuctionEvent is emitted with updates on a symbol's auction
+type AuctionEvent struct {
+	EventId int64
+	Result string
+	AuctionPrice float64
+	AuctionQuantity float64
+}
+
+type rawEvent struct {
+	Type string `json:"type"`
+	Side string `json:"side"`
+	Price string `json:"price"`
+	Remaining string `json:"remaining"`
+	Delta string `json:"delta"`
+	Reason string `json:"reason"`
+	TradeId int64 `json:"tid"`
+	Amount string `json:"amount"`
+	MakerSide string `json:"makerSide"`
+	Result string `json:"result"`
+	AuctionPrice string `json:"auction_price"`
+	AuctionQuantity string `json:"auction_quantity"`
+}
+
+type rawMessage struct {
+	Type string `json:"type"`
+	EventId int64 `json:"eventId"`
+	Events []rawEvent `json:"events"`
+}
+
+// l2Book is a local level-2 order book kept in sync by applying change events
+// starting from the initial snapshot
+type l2Book struct {
+	mu sync.RWMutex
+	bids map[float64]float64
+	asks map[float64]float64
+}
+
+func newL2Book() *l2Book {
+	return &l2Book{
+		bids: map[float64]float64{},
+		asks: map[float64]float64{},
+	}
+}
+
+func (b *l2Book) applyChange(ev ChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	side := b.bids
+	if ev.Side == "ask" {
+		side = b.asks
+	}
+	if ev.Remaining == 0 {
+		delete(side, ev.Price)
+		return
+	}
+	side[ev.Price] = ev.Remaining
+}
+
+func (b *l2Book) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids = map[float64]float64{}
+	b.asks = map[float64]float64{}
+}
+
+func (b *l2Book) snapshot() (map[float64]float64, map[float64]float64) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	bids := make(map[float64]float64, len(b.bids))
+	for p, a := range b.bids {
+		bids[p] = a
+	}
+	asks := make(map[float64]float64, len(b.asks))
+	for p, a := range b.asks {
+		asks[p] = a
+	}
+	return bids, asks
+}
+
+// MarketDataClient streams order book changes, trades, and auction events for a
+// single symbol, maintaining a local level-2 order book and reconnecting
+// automatically with exponential backoff.
+type MarketDataClient struct {
+	BaseURL string
+	Symbol string
+
+	Changes chan ChangeEvent
+	Trades chan TradeEvent
+	Auctions chan AuctionEvent
+
+	book *l2Book
+	mu sync.Mutex
+	conn *websocket.Conn
+	subscribed bool
+	closed chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMarketDataClient creates a client for symbol against baseURL (e.g. "wss://api.gemini.com")
+func NewMarketDataClient(baseURL, symbol string) *MarketDataClient {
+	return &MarketDataClient{
+		BaseURL: baseURL,
+		Symbol: symbol,
+		Changes: make(chan ChangeEvent, 256),
+		Trades: make(chan TradeEvent, 256),
+		Auctions: make(chan AuctionEvent, 64),
+		book: newL2Book(),
+		closed: make(chan struct{}),
+	}
+}
+
+// Subscribe connects to the marketdata feed and begins dispatching events.
+// It reconnects automatically on disconnect until Unsubscribe or Close is called.
+func (c *MarketDataClient) Subscribe() error {
+	c.mu.Lock()
+	c.subscribed = true
+	c.mu.Unlock()
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+	go c.readLoop()
+	return nil
+}
+
+func (c *MarketDataClient) dial() (*websocket.Conn, error) {
+	url := fmt.Sprintf("%s/v1/marketdata/%s?heartbeat=true", c.BaseURL, c.Symbol)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	return conn, err
+}
+
+func (c *MarketDataClient) readLoop() {
+	bo := newBackoff(time.Second, 30*time.Second)
+	for {
+		c.mu.Lock()
+		conn := c.conn
+		c.mu.Unlock()
+		if conn == nil {
+			return
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-c.closed:
+				return
+			default:
+			}
+			c.mu.Lock()
+			subscribed := c.subscribed
+			c.mu.Unlock()
+			if !subscribed {
+				return
+			}
+			log.Printf("ERROR: marketdata connection for %s dropped: %s\n", c.Symbol, err)
+			conn.Close()
+			time.Sleep(bo.next())
+			newConn, err := c.dial()
+			if err != nil {
+				log.Printf("ERROR: marketdata reconnect for %s failed: %s\n", c.Symbol, err)
+				continue
+			}
+			c.mu.Lock()
+			if !c.subscribed {
+				c.mu.Unlock()
+				newConn.Close()
+				return
+			}
+			c.book.reset()
+			bo.reset()
+			c.conn = newConn
+			c.mu.Unlock()
+			continue
+		}
+		msg := rawMessage{}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			log.Printf("ERROR: failed to decode marketdata message for %s: %s\n", c.Symbol, err)
+			continue
+		}
+		c.dispatch(msg)
+	}
+}
+
+func (c *MarketDataClient) dispatch(msg rawMessage) {
+	for _, ev := range msg.Events {
+		switch ev.Type {
+		case "change":
+			price, _ := strconv.ParseFloat(ev.Price, 64)
+			remaining, _ := strconv.ParseFloat(ev.Remaining, 64)
+			delta, _ := strconv.ParseFloat(ev.Delta, 64)
+			change := ChangeEvent{Side: ev.Side, Price: price, Remaining: remaining, Delta: delta, Reason: ev.Reason}
+			c.book.applyChange(change)
+			select {
+			case c.Changes <- change:
+			default:
+			}
+		case "trade":
+			price, _ := strconv.ParseFloat(ev.Price, 64)
+			amount, _ := strconv.ParseFloat(ev.Amount, 64)
+			select {
+			case c.Trades <- TradeEvent{TradeId: ev.TradeId, Price: price, Amount: amount, MakerSide: ev.MakerSide}:
+			default:
+			}
+		case "auction":
+			auctionPrice, _ := strconv.ParseFloat(ev.AuctionPrice, 64)
+			auctionQuantity, _ := strconv.ParseFloat(ev.AuctionQuantity, 64)
+			select {
+			case c.Auctions <- AuctionEvent{EventId: msg.EventId, Result: ev.Result, AuctionPrice: auctionPrice, AuctionQuantity: auctionQuantity}:
+			default:
+			}
+		}
+	}
+}
+
+// Book returns a snapshot copy of the locally maintained bid/ask price levels
+func (c *MarketDataClient) Book() (bids, asks map[float64]float64) {
+	return c.book.snapshot()
+}
+
+// Unsubscribe closes the current connection and stops future reconnects.
+// Call Subscribe again to resume streaming.
+func (c *MarketDataClient) Unsubscribe() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribed = false
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// Close permanently stops the client and releases its connection. It is
+// safe to call more than once.
+func (c *MarketDataClient) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.Unsubscribe()
+}
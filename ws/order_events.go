@@ -0,0 +1,195 @@
+package ws
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// OrderEvent is a single message from the /v1/order/events feed: a heartbeat,
+// subscription ack, or an order lifecycle update (accepted/booked/fill/cancelled/...)
+type OrderEvent struct {
+	Type string `json:"type"`
+	OrderId string `json:"order_id"`
+	ClientOrderId string `json:"client_order_id"`
+	Symbol string `json:"symbol"`
+	Side string `json:"side"`
+	OrderType string `json:"order_type"`
+	Behavior string `json:"behavior"`
+	Price float64 `json:"price,string"`
+	OriginalAmount float64 `json:"original_amount,string"`
+	ExecutedAmount float64 `json:"executed_amount,string"`
+	RemainingAmount float64 `json:"remaining_amount,string"`
+	AvgExecutionPrice float64 `json:"avg_execution_price,string"`
+	Timestampms int64 `json:"timestampms"`
+}
+
+// OrderEventsClient streams authenticated order lifecycle events for the
+// account that owns the given api key/secret, reconnecting automatically
+// with exponential backoff and re-subscribing on every reconnect.
+type OrderEventsClient struct {
+	BaseURL string
+	ApiKey string
+	ApiSecret string
+
+	Events chan OrderEvent
+
+	nonce int64
+	mu sync.Mutex
+	conn *websocket.Conn
+	subscribed bool
+	closed chan struct{}
+	closeOnce sync.Once
+}
+
+// NewOrderEventsClient creates a client against baseURL (e.g. "wss://api.gemini.com")
+func NewOrderEventsClient(baseURL, apiKey, apiSecret string) *OrderEventsClient {
+	return &OrderEventsClient{
+		BaseURL: baseURL,
+		ApiKey: apiKey,
+		ApiSecret: apiSecret,
+		Events: make(chan OrderEvent, 256),
+		nonce: time.Now().UnixNano(),
+		closed: make(chan struct{}),
+	}
+}
+
+// authHeaders signs the /v1/order/events request the same way AuthAPIReq signs
+// REST requests: a base64 JSON payload HMAC-SHA384'd with the api secret
+func (c *OrderEventsClient) authHeaders() http.Header {
+	nonce := atomic.AddInt64(&c.nonce, 1)
+	payload := fmt.Sprintf(`{"request":"/v1/order/events","nonce":%d}`, nonce)
+	base64Payload := base64.StdEncoding.EncodeToString([]byte(payload))
+	h := hmac.New(sha512.New384, []byte(c.ApiSecret))
+	h.Write([]byte(base64Payload))
+	sig := h.Sum(nil)
+	header := http.Header{}
+	header.Add("X-GEMINI-APIKEY", c.ApiKey)
+	header.Add("X-GEMINI-PAYLOAD", base64Payload)
+	header.Add("X-GEMINI-SIGNATURE", hex.EncodeToString(sig))
+	return header
+}
+
+// Subscribe connects to the order events feed and begins dispatching events.
+// It reconnects automatically on disconnect until Unsubscribe or Close is called.
+func (c *OrderEventsClient) Subscribe() error {
+	c.mu.Lock()
+	c.subscribed = true
+	c.mu.Unlock()
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+	go c.readLoop()
+	return nil
+}
+
+func (c *OrderEventsClient) dial() (*websocket.Conn, error) {
+	url := fmt.Sprintf("%s/v1/order/events", c.BaseURL)
+	conn, _, err := websocket.DefaultDialer.Dial(url, c.authHeaders())
+	return conn, err
+}
+
+func (c *OrderEventsClient) readLoop() {
+	bo := newBackoff(time.Second, 30*time.Second)
+	for {
+		c.mu.Lock()
+		conn := c.conn
+		c.mu.Unlock()
+		if conn == nil {
+			return
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-c.closed:
+				return
+			default:
+			}
+			c.mu.Lock()
+			subscribed := c.subscribed
+			c.mu.Unlock()
+			if !subscribed {
+				return
+			}
+			log.Printf("ERROR: order events connection dropped: %s\n", err)
+			conn.Close()
+			time.Sleep(bo.next())
+			newConn, err := c.dial()
+			if err != nil {
+				log.Printf("ERROR: order events reconnect failed: %s\n", err)
+				continue
+			}
+			c.mu.Lock()
+			if !c.subscribed {
+				c.mu.Unlock()
+				newConn.Close()
+				return
+			}
+			bo.reset()
+			c.conn = newConn
+			c.mu.Unlock()
+			continue
+		}
+		c.dispatch(data)
+	}
+}
+
+func (c *OrderEventsClient) dispatch(data []byte) {
+	trimmed := bytes.TrimSpace(data)
+	events := []OrderEvent{}
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &events); err != nil {
+			log.Printf("ERROR: failed to decode order events batch: %s\n", err)
+			return
+		}
+	} else {
+		event := OrderEvent{}
+		if err := json.Unmarshal(trimmed, &event); err != nil {
+			log.Printf("ERROR: failed to decode order event: %s\n", err)
+			return
+		}
+		events = append(events, event)
+	}
+	for _, event := range events {
+		select {
+		case c.Events <- event:
+		default:
+		}
+	}
+}
+
+// Unsubscribe closes the current connection and stops future reconnects.
+// Call Subscribe again to resume streaming.
+func (c *OrderEventsClient) Unsubscribe() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribed = false
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// Close permanently stops the client and releases its connection. It is
+// safe to call more than once.
+func (c *OrderEventsClient) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.Unsubscribe()
+}